@@ -0,0 +1,135 @@
+package death
+
+import (
+	"sync"
+	"time"
+)
+
+// Timer is the subset of *time.Timer's behavior a Clock needs to provide.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// Clock abstracts time so that timeout-driven code such as closeInMass can be
+// tested deterministically instead of requiring real sleeps.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	After(d time.Duration) <-chan time.Time
+}
+
+// SetClock overrides the Clock Death uses for timeouts. Defaults to the real,
+// wall-clock implementation; tests can substitute a MockClock to exercise timeout
+// branches without waiting on the real clock.
+func (d *Death) SetClock(c Clock) *Death {
+	d.clock = c
+	return d
+}
+
+// realClock is the default Clock, backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{t: time.NewTimer(d)}
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// realTimer adapts *time.Timer to the Timer interface.
+type realTimer struct {
+	t *time.Timer
+}
+
+func (r *realTimer) C() <-chan time.Time { return r.t.C }
+func (r *realTimer) Stop() bool          { return r.t.Stop() }
+
+// MockClock is a manually-advanced Clock for deterministic tests. The clock does
+// not move on its own; call Advance to move it forward and fire any timers whose
+// deadline has been reached.
+type MockClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*mockTimer
+}
+
+// NewMockClock returns a MockClock starting at the Unix epoch.
+func NewMockClock() *MockClock {
+	return &MockClock{now: time.Unix(0, 0)}
+}
+
+// Now returns the mock clock's current time.
+func (c *MockClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a Timer that fires once the mock clock has been Advance'd by at
+// least d from the current time. As with time.NewTimer, a non-positive d fires
+// immediately rather than waiting for a future Advance.
+func (c *MockClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	deadline := c.now.Add(d)
+	t := &mockTimer{c: make(chan time.Time, 1), deadline: deadline, clock: c}
+	if !deadline.After(c.now) {
+		t.c <- c.now
+		return t
+	}
+	c.waiters = append(c.waiters, t)
+	return t
+}
+
+// After is shorthand for NewTimer(d).C().
+func (c *MockClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Advance moves the mock clock forward by d, firing any pending timers whose
+// deadline has been reached.
+func (c *MockClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	remaining := c.waiters[:0]
+	for _, t := range c.waiters {
+		if t.stopped {
+			continue
+		}
+		if !t.deadline.After(c.now) {
+			select {
+			case t.c <- c.now:
+			default:
+			}
+			continue
+		}
+		remaining = append(remaining, t)
+	}
+	c.waiters = remaining
+}
+
+// mockTimer is the Timer implementation handed out by MockClock.
+type mockTimer struct {
+	c        chan time.Time
+	deadline time.Time
+	clock    *MockClock
+	stopped  bool
+}
+
+func (t *mockTimer) C() <-chan time.Time { return t.c }
+
+func (t *mockTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	return true
+}
@@ -0,0 +1,73 @@
+package death
+
+import (
+	"context"
+	"fmt"
+)
+
+// ContextCloser is a shutdown callback that takes a context instead of a bare
+// Close() error.
+type ContextCloser func(ctx context.Context) error
+
+// Add registers one or more context-aware shutdown functions to be invoked by
+// WaitForDeathContext, in addition to any passed directly to that call. It is
+// safe to call from any goroutine at any time before shutdown begins.
+func (d *Death) Add(fns ...ContextCloser) *Death {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ctxClosers = append(d.ctxClosers, fns...)
+	return d
+}
+
+// WaitForDeathContext waits for a signal and then calls every registered
+// ContextCloser (see Add) plus the ones passed here, each with a context derived
+// from ctx and the Death's timeout. A non-nil return means the timeout expired
+// before everything closed; individual errors are only logged.
+func (d *Death) WaitForDeathContext(ctx context.Context, fns ...func(context.Context) error) (err error) {
+	d.wg.Wait()
+	d.log.Info("Shutdown started...")
+
+	d.mu.Lock()
+	all := make([]ContextCloser, 0, len(d.ctxClosers)+len(fns))
+	all = append(all, d.ctxClosers...)
+	for _, f := range fns {
+		all = append(all, f)
+	}
+	d.mu.Unlock()
+
+	count := len(all)
+	d.log.Debug("Closing ", count, " object(s)")
+	if count == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	done := make(chan struct{}, count)
+	for _, f := range all {
+		go d.closeWithContext(ctx, f, done)
+	}
+
+	for count > 0 {
+		select {
+		case <-ctx.Done():
+			d.log.Warn(count, " object(s) remaining but context expired.")
+			return fmt.Errorf("failed to close all objects")
+		case <-done:
+			count--
+			d.log.Debug(count, " object(s) left")
+		}
+	}
+	d.log.Debug("Finished closing objects")
+	return nil
+}
+
+// closeWithContext calls f and signals done, logging (but not propagating) any
+// error it returns.
+func (d *Death) closeWithContext(ctx context.Context, f ContextCloser, done chan<- struct{}) {
+	if err := f(ctx); err != nil {
+		d.log.Error(err)
+	}
+	done <- struct{}{}
+}
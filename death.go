@@ -19,8 +19,17 @@ type Death struct {
 	wg          *sync.WaitGroup
 	sigChannel  chan os.Signal
 	callChannel chan struct{}
+	signals     []os.Signal
 	timeout     time.Duration
 	log         Logger
+	clock       Clock
+
+	mu              sync.Mutex
+	ctxClosers      []ContextCloser
+	signalReceived  os.Signal
+	forceExitCode   *int
+	registered      []io.Closer
+	shutdownStarted bool
 }
 
 // Logger interface to log.
@@ -45,8 +54,10 @@ func NewDeath(signals ...os.Signal) (death *Death) {
 	death = &Death{timeout: 10 * time.Second,
 		sigChannel:  make(chan os.Signal, 1),
 		callChannel: make(chan struct{}, 1),
+		signals:     signals,
 		wg:          &sync.WaitGroup{},
-		log:         log.StandardLogger()}
+		log:         log.StandardLogger(),
+		clock:       realClock{}}
 	signal.Notify(death.sigChannel, signals...)
 	death.wg.Add(1)
 	go death.listenForSignal()
@@ -66,14 +77,18 @@ func (d *Death) SetLogger(l Logger) *Death {
 }
 
 // WaitForDeath wait for signal and then kill all items that need to die. If they fail to
-// die when instructed we return an error
+// die when instructed we return an error. Called with no arguments, it closes whatever
+// has been queued up with Register.
 func (d *Death) WaitForDeath(closable ...io.Closer) (err error) {
 	d.wg.Wait()
 	d.log.Info("Shutdown started...")
-	count := len(closable)
+
+	all := append(d.drainRegistered(), closable...)
+
+	count := len(all)
 	d.log.Debug("Closing ", count, " objects")
 	if count > 0 {
-		return d.closeInMass(closable...)
+		return d.closeInMass(all...)
 	}
 	return nil
 }
@@ -98,6 +113,12 @@ func getPkgPath(c io.Closer) (name string, pkgPath string) {
 // closeInMass Close all the objects at once and wait for them to finish with a channel. Return an
 // error if you fail to close all the objects
 func (d *Death) closeInMass(closable ...io.Closer) (err error) {
+	return d.closeInMassWithTimeout(d.timeout, closable...)
+}
+
+// closeInMassWithTimeout is closeInMass but with an explicit timeout, so phased
+// shutdown can give each phase its own deadline instead of always using d.timeout.
+func (d *Death) closeInMassWithTimeout(timeout time.Duration, closable ...io.Closer) (err error) {
 
 	count := len(closable)
 	sentToClose := make(map[int]closer)
@@ -111,10 +132,10 @@ func (d *Death) closeInMass(closable ...io.Closer) (err error) {
 	}
 
 	// wait on channel for notifications.
-	timer := time.NewTimer(d.timeout)
+	timer := d.clock.NewTimer(timeout)
 	for {
 		select {
-		case <-timer.C:
+		case <-timer.C():
 			d.log.Warn(count, " object(s) remaining but timer expired.")
 			for _, c := range sentToClose {
 				d.log.Error("Failed to close: ", c.PKGPath, "/", c.Name)
@@ -152,12 +173,17 @@ func (d *Death) FallOnSword() {
 // ListenForSignal Manage death of application by signal.
 func (d *Death) listenForSignal() {
 	defer d.wg.Done()
-	for {
-		select {
-		case <-d.sigChannel:
-			return
-		case <-d.callChannel:
-			return
+	select {
+	case sig := <-d.sigChannel:
+		d.mu.Lock()
+		d.signalReceived = sig
+		forceExitCode := d.forceExitCode
+		d.mu.Unlock()
+		if forceExitCode != nil {
+			go d.watchForSecondSignal(sig, *forceExitCode)
 		}
+		return
+	case <-d.callChannel:
+		return
 	}
 }
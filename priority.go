@@ -0,0 +1,85 @@
+package death
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PriorityGroup is one phase of a prioritized shutdown: a set of closers that are
+// closed together, plus the timeout allotted to that phase. A zero Timeout falls
+// back to the Death's overall timeout (see SetTimeout).
+type PriorityGroup struct {
+	Closers []io.Closer
+	Timeout time.Duration
+}
+
+// PhaseError describes the failure of a single phase of a prioritized shutdown.
+type PhaseError struct {
+	Phase int
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *PhaseError) Error() string {
+	return "phase " + strconv.Itoa(e.Phase) + ": " + e.Err.Error()
+}
+
+// Unwrap allows errors.Is/As to reach the underlying phase error.
+func (e *PhaseError) Unwrap() error {
+	return e.Err
+}
+
+// PhasedShutdownError aggregates the errors of every phase that failed to close in
+// time during a WaitForDeathWithPriority call.
+type PhasedShutdownError []*PhaseError
+
+// Error implements the error interface.
+func (e PhasedShutdownError) Error() string {
+	msgs := make([]string, len(e))
+	for i, pe := range e {
+		msgs[i] = pe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// WaitForDeathWithPriority waits for a signal and then closes each PriorityGroup
+// in order: phase N+1 does not start until phase N has finished closing (or its
+// timeout has expired). The overall Death timeout still caps the total time spent
+// across all phases. Errors from individual phases are collected into a
+// PhasedShutdownError; a nil return means every phase closed cleanly.
+func (d *Death) WaitForDeathWithPriority(groups ...PriorityGroup) (err error) {
+	d.wg.Wait()
+	d.log.Info("Shutdown started...")
+
+	if registered := d.drainRegistered(); len(registered) > 0 {
+		groups = append(groups, PriorityGroup{Closers: registered})
+	}
+
+	var errs PhasedShutdownError
+	deadline := d.clock.Now().Add(d.timeout)
+	for i, g := range groups {
+		if len(g.Closers) == 0 {
+			continue
+		}
+
+		timeout := g.Timeout
+		if timeout <= 0 {
+			timeout = d.timeout
+		}
+		if remaining := deadline.Sub(d.clock.Now()); remaining < timeout {
+			timeout = remaining
+		}
+
+		d.log.Debug("Closing phase ", i, " (", len(g.Closers), " object(s))")
+		if perr := d.closeInMassWithTimeout(timeout, g.Closers...); perr != nil {
+			errs = append(errs, &PhaseError{Phase: i, Err: perr})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package death
+
+import "io"
+
+// Register queues one or more closers to be closed by a subsequent no-argument
+// WaitForDeath() call, in addition to whatever closers are passed directly to it.
+// This is for resources constructed after NewDeath - DB pools opened once config
+// loads, plugins discovered at runtime, per-request subsystems - that can't be
+// collected up front. Safe to call from any goroutine at any time. If shutdown has
+// already started, the closer is closed immediately instead of being queued.
+func (d *Death) Register(closers ...io.Closer) (err error) {
+	d.mu.Lock()
+	if d.shutdownStarted {
+		d.mu.Unlock()
+		for _, c := range closers {
+			if cerr := c.Close(); cerr != nil {
+				err = cerr
+			}
+		}
+		return err
+	}
+	d.registered = append(d.registered, closers...)
+	d.mu.Unlock()
+	return nil
+}
+
+// Unregister removes previously Register'd closers so they are no longer closed
+// by WaitForDeath(). It is a no-op for closers that were never registered, or for
+// registrations made after shutdown had already started, since those were already
+// closed immediately by Register.
+func (d *Death) Unregister(closers ...io.Closer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, c := range closers {
+		for i, r := range d.registered {
+			if closerEqual(r, c) {
+				d.registered = append(d.registered[:i], d.registered[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// closerEqual compares two closers for identity. io.Closer implementations are
+// occasionally backed by non-comparable types (e.g. a struct holding a slice or
+// map), which would make a plain == panic; treat those as never matching rather
+// than crashing the caller's shutdown path.
+func closerEqual(a, b io.Closer) (eq bool) {
+	defer func() {
+		if recover() != nil {
+			eq = false
+		}
+	}()
+	return a == b
+}
+
+// drainRegistered marks shutdown as started and returns (and clears) everything
+// queued up via Register, so WaitForDeath, WaitForDeathWithPriority and
+// WaitForDeathReport pick up registered closers and so Register calls made
+// afterwards close immediately rather than being queued with no one left to drain
+// them. WaitForDeathContext and WaitForDeathWithFunc do not call it: they neither
+// close registered closers nor mark shutdown as started.
+func (d *Death) drainRegistered() []io.Closer {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.shutdownStarted = true
+	registered := d.registered
+	d.registered = nil
+	return registered
+}
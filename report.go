@@ -0,0 +1,165 @@
+package death
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// ShutdownEntry records what happened when a single closer was closed as part of
+// a WaitForDeathReport shutdown.
+type ShutdownEntry struct {
+	PKGPath  string
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+	TimedOut bool
+}
+
+// failed reports whether this entry should count towards ShutdownReport.Failed.
+func (e ShutdownEntry) failed() bool {
+	return e.TimedOut || e.Err != nil
+}
+
+// ShutdownReport is the detailed result of a WaitForDeathReport shutdown. It
+// implements error so callers can still treat the result as a plain error.
+type ShutdownReport struct {
+	entries []ShutdownEntry
+	total   time.Duration
+}
+
+// Error implements the error interface, summarizing any failed closers.
+func (r *ShutdownReport) Error() string {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return ""
+	}
+	msgs := make([]string, len(failed))
+	for i, e := range failed {
+		switch {
+		case e.TimedOut:
+			msgs[i] = e.PKGPath + "/" + e.Name + ": timed out"
+		default:
+			msgs[i] = e.PKGPath + "/" + e.Name + ": " + e.Err.Error()
+		}
+	}
+	return "failed to close all objects: " + strings.Join(msgs, "; ")
+}
+
+// Failed returns the entries that errored or timed out.
+func (r *ShutdownReport) Failed() []ShutdownEntry {
+	var failed []ShutdownEntry
+	for _, e := range r.entries {
+		if e.failed() {
+			failed = append(failed, e)
+		}
+	}
+	return failed
+}
+
+// Succeeded returns the entries that closed cleanly.
+func (r *ShutdownReport) Succeeded() []ShutdownEntry {
+	var succeeded []ShutdownEntry
+	for _, e := range r.entries {
+		if !e.failed() {
+			succeeded = append(succeeded, e)
+		}
+	}
+	return succeeded
+}
+
+// TotalDuration returns how long the whole shutdown took.
+func (r *ShutdownReport) TotalDuration() time.Duration {
+	return r.total
+}
+
+// WaitForDeathReport is WaitForDeath but returns a ShutdownReport with per-closer
+// detail instead of collapsing everything into a single error string. err is
+// non-nil whenever report.Failed() is non-empty.
+func (d *Death) WaitForDeathReport(closable ...io.Closer) (report *ShutdownReport, err error) {
+	d.wg.Wait()
+	d.log.Info("Shutdown started...")
+
+	closable = append(d.drainRegistered(), closable...)
+	count := len(closable)
+	d.log.Debug("Closing ", count, " objects")
+	if count == 0 {
+		return &ShutdownReport{}, nil
+	}
+
+	report = d.closeInMassReport(d.timeout, closable...)
+	if len(report.Failed()) > 0 {
+		return report, report
+	}
+	return report, nil
+}
+
+// closerResult carries a completed ShutdownEntry back to closeInMassReport,
+// tagged with the index needed to cross it off the in-flight set.
+type closerResult struct {
+	Index int
+	entry ShutdownEntry
+}
+
+// closeInMassReport is closeInMass but produces a ShutdownReport instead of a
+// single error.
+func (d *Death) closeInMassReport(timeout time.Duration, closable ...io.Closer) *ShutdownReport {
+	report := &ShutdownReport{}
+	overallStart := d.clock.Now()
+
+	count := len(closable)
+	sentToClose := make(map[int]closer)
+	doneClosers := make(chan closerResult, count)
+	for i, c := range closable {
+		name, pkgPath := getPkgPath(c)
+		cl := closer{Index: i, C: c, Name: name, PKGPath: pkgPath}
+		sentToClose[i] = cl
+		go d.closeObjectsReport(cl, doneClosers)
+	}
+
+	timer := d.clock.NewTimer(timeout)
+	for {
+		select {
+		case <-timer.C():
+			for _, c := range sentToClose {
+				d.log.Error("Failed to close: ", c.PKGPath, "/", c.Name)
+				report.entries = append(report.entries, ShutdownEntry{
+					PKGPath:  c.PKGPath,
+					Name:     c.Name,
+					TimedOut: true,
+				})
+			}
+			report.total = d.clock.Now().Sub(overallStart)
+			return report
+		case res := <-doneClosers:
+			delete(sentToClose, res.Index)
+			count--
+			report.entries = append(report.entries, res.entry)
+			if count == 0 && len(sentToClose) == 0 {
+				report.total = d.clock.Now().Sub(overallStart)
+				return report
+			}
+		}
+	}
+}
+
+// closeObjectsReport is closeObjects but records a ShutdownEntry instead of
+// returning a closer on the done channel.
+func (d *Death) closeObjectsReport(c closer, done chan<- closerResult) {
+	start := d.clock.Now()
+	err := c.C.Close()
+	if err != nil {
+		d.log.Error(err)
+	}
+	done <- closerResult{
+		Index: c.Index,
+		entry: ShutdownEntry{
+			PKGPath:  c.PKGPath,
+			Name:     c.Name,
+			Start:    start,
+			Duration: d.clock.Now().Sub(start),
+			Err:      err,
+		},
+	}
+}
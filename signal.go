@@ -0,0 +1,39 @@
+package death
+
+import (
+	"os"
+	"os/signal"
+)
+
+// ForceOnSecondSignal keeps Death listening for signals past the first one: if the
+// same signal arrives again while shutdown is still in flight, Death resets the
+// signal handlers and calls os.Exit(exitCode) instead of waiting on a possibly
+// hung closer. This gives users the familiar "hit Ctrl-C twice to really quit".
+func (d *Death) ForceOnSecondSignal(exitCode int) *Death {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.forceExitCode = &exitCode
+	return d
+}
+
+// SignalReceived returns the signal that triggered shutdown, or nil if shutdown
+// was triggered by FallOnSword or hasn't happened yet.
+func (d *Death) SignalReceived() os.Signal {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.signalReceived
+}
+
+// watchForSecondSignal keeps reading d.sigChannel after the first signal has
+// already kicked off shutdown. Receiving the same signal again means the caller
+// wants out now, so we stop listening for signals and exit immediately.
+func (d *Death) watchForSecondSignal(first os.Signal, exitCode int) {
+	for sig := range d.sigChannel {
+		if sig == first {
+			d.log.Warn("Received second ", sig, ", forcing shutdown.")
+			signal.Reset(d.signals...)
+			os.Exit(exitCode)
+			return
+		}
+	}
+}